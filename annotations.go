@@ -0,0 +1,136 @@
+package opencagedata
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Components breaks a result's formatted address down into its parts.
+//
+// OpenCage derives these from OpenStreetMap data, so not every field is
+// present for every result (a rural address may have no Postcode, a city
+// centre may have no HouseNumber, and so on).
+type Components struct {
+	Country     string `json:"country,omitempty"`
+	CountryCode string `json:"country_code,omitempty"`
+	State       string `json:"state,omitempty"`
+	County      string `json:"county,omitempty"`
+	City        string `json:"city,omitempty"`
+	Suburb      string `json:"suburb,omitempty"`
+	Postcode    string `json:"postcode,omitempty"`
+	Road        string `json:"road,omitempty"`
+	HouseNumber string `json:"house_number,omitempty"`
+	Continent   string `json:"continent,omitempty"`
+
+	// Category and Type are OpenCage's own classification of the place,
+	// e.g. "_category": "road" / "_type": "motorway".
+	Category string `json:"_category,omitempty"`
+	Type     string `json:"_type,omitempty"`
+}
+
+// Annotations holds the extra, non-address metadata OpenCage attaches to a
+// result. Most fields are only populated when the corresponding data is
+// available for the queried location, so check for the zero value (or a nil
+// pointer, for the struct-typed ones) before using them.
+type Annotations struct {
+	Timezone    *Timezone   `json:"timezone,omitempty"`
+	Currency    *Currency   `json:"currency,omitempty"`
+	Sun         *Sun        `json:"sun,omitempty"`
+	What3Words  *What3Words `json:"what3words,omitempty"`
+	MGRS        *MGRS       `json:"MGRS,omitempty"`
+	Maidenhead  string      `json:"maidenhead,omitempty"`
+	Mercator    *Mercator   `json:"mercator,omitempty"`
+	OSM         *OSM        `json:"OSM,omitempty"`
+	RoadInfo    *RoadInfo   `json:"roadinfo,omitempty"`
+	Qibla       float64     `json:"qibla,omitempty"`
+	CallingCode int         `json:"callingcode,omitempty"`
+	Flag        string      `json:"flag,omitempty"`
+	Geohash     string      `json:"geohash,omitempty"`
+	DMS         *DMS        `json:"DMS,omitempty"`
+}
+
+type Timezone struct {
+	Name      string `json:"name"`
+	OffsetSec int    `json:"offset_sec"`
+}
+
+type Currency struct {
+	ISOCode     string `json:"iso_code"`
+	Symbol      string `json:"symbol"`
+	DecimalMark string `json:"decimal_mark"`
+}
+
+// Sun holds today's sunrise and sunset for the result, as reported by
+// OpenCage's sun annotation.
+type Sun struct {
+	Rise SunPhases `json:"rise"`
+	Set  SunPhases `json:"set"`
+}
+
+// SunPhases is when a sunrise or sunset occurs, depending on how much
+// twilight is counted as part of it. Apparent is the moment most people
+// mean by "sunrise"/"sunset"; the others progressively widen the window.
+type SunPhases struct {
+	Apparent     UnixTime `json:"apparent"`
+	Astronomical UnixTime `json:"astronomical"`
+	Civil        UnixTime `json:"civil"`
+	Nautical     UnixTime `json:"nautical"`
+}
+
+type What3Words struct {
+	Words string `json:"words"`
+}
+
+// MGRS is the location expressed as a Military Grid Reference System
+// coordinate. Precision is the grid's resolution in metres.
+type MGRS struct {
+	Zone      string `json:"zone"`
+	Easting   string `json:"easting"`
+	Northing  string `json:"northing"`
+	Precision int    `json:"precision"`
+}
+
+// Mercator is the location projected into Web Mercator (EPSG:3857) metres.
+type Mercator struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+type OSM struct {
+	URL     string `json:"url"`
+	EditURL string `json:"edit_url"`
+	NoteURL string `json:"note_url"`
+}
+
+// RoadInfo describes the road the result sits on, when OpenCage can
+// determine one.
+type RoadInfo struct {
+	DriveOn  string `json:"drive_on"`
+	SpeedIn  string `json:"speed_in"`
+	Road     string `json:"road"`
+	RoadType string `json:"road_type"`
+}
+
+// DMS is the location expressed in degrees/minutes/seconds rather than
+// decimal degrees.
+type DMS struct {
+	Lat string `json:"lat"`
+	Lng string `json:"lng"`
+}
+
+// UnixTime decodes a JSON integer holding Unix seconds into a time.Time.
+// OpenCage reports sunrise/sunset this way in the sun annotation.
+type UnixTime time.Time
+
+func (t *UnixTime) UnmarshalJSON(data []byte) error {
+	var sec int64
+	if err := json.Unmarshal(data, &sec); err != nil {
+		return err
+	}
+	*t = UnixTime(time.Unix(sec, 0))
+	return nil
+}
+
+func (t UnixTime) Time() time.Time {
+	return time.Time(t)
+}
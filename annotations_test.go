@@ -0,0 +1,93 @@
+package opencagedata
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// fullAnnotationsPayload is OpenCage's documented sample annotations
+// response, with sun.rise/sun.set as multi-phase objects and MGRS.precision
+// as a number - the shapes this test exists to guard.
+const fullAnnotationsPayload = `{
+	"status": {"code": 200, "message": "OK"},
+	"rate": {"limit": 2500, "remaining": 2499, "reset": 0},
+	"results": [{
+		"formatted": "Fonteinstraat, 3000 Leuven, Belgium",
+		"components": {"country": "Belgium", "country_code": "be"},
+		"annotations": {
+			"DMS": {"lat": "50° 52' 44.55588'' N", "lng": "4° 41' 47.87376'' E"},
+			"MGRS": {"zone": "31U DS 89053 67372", "easting": "89053", "northing": "67372", "precision": 1},
+			"Maidenhead": "JO20gu90vt",
+			"mercator": {"x": 521481.92, "y": 6595938.01},
+			"OSM": {"url": "https://www.openstreetmap.org/", "edit_url": "https://www.openstreetmap.org/edit", "note_url": "https://www.openstreetmap.org/note/new"},
+			"callingcode": 32,
+			"currency": {"iso_code": "EUR", "symbol": "€", "decimal_mark": ","},
+			"flag": "🇧🇪",
+			"geohash": "u150vq2summ",
+			"qibla": 123.46,
+			"roadinfo": {"drive_on": "right", "speed_in": "km/h", "road": "Fonteinstraat", "road_type": "residential"},
+			"sun": {
+				"rise": {"apparent": 1586922960, "astronomical": 1586912760, "civil": 1586920020, "nautical": 1586916840},
+				"set": {"apparent": 1586973480, "astronomical": 1586983680, "civil": 1586976420, "nautical": 1586979600}
+			},
+			"timezone": {"name": "Europe/Brussels", "offset_sec": 7200},
+			"what3words": {"words": "chess.lake.irony"}
+		}
+	}]
+}`
+
+// TestAnnotationsDecodeFullPayload guards against annotation field shapes
+// drifting from what OpenCage actually sends - sun.rise/sun.set are
+// multi-phase objects rather than bare Unix times, and MGRS.precision is a
+// JSON number rather than a string. Before this, either mismatch failed
+// decoding of the whole GeocodeResult, not just the offending field, since
+// doGeocode treats any decode error as a hard failure and annotations are
+// on by default.
+func TestAnnotationsDecodeFullPayload(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(fullAnnotationsPayload))
+	}))
+	defer srv.Close()
+
+	g := &Geocoder{Key: "test", BaseURL: srv.URL + "/", DisableRateLimitSleep: true}
+
+	result, err := g.GeocodeContext(context.Background(), "Fonteinstraat, Leuven", nil)
+	if err != nil {
+		t.Fatalf("GeocodeContext returned error: %v", err)
+	}
+	if len(result.Results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(result.Results))
+	}
+
+	ann := result.Results[0].Annotations
+
+	if ann.MGRS == nil || ann.MGRS.Precision != 1 {
+		t.Fatalf("unexpected MGRS: %+v", ann.MGRS)
+	}
+
+	if ann.Sun == nil {
+		t.Fatal("expected Sun annotation to be populated")
+	}
+	wantRise := time.Unix(1586922960, 0)
+	if !ann.Sun.Rise.Apparent.Time().Equal(wantRise) {
+		t.Fatalf("Sun.Rise.Apparent = %v, want %v", ann.Sun.Rise.Apparent.Time(), wantRise)
+	}
+	wantSet := time.Unix(1586973480, 0)
+	if !ann.Sun.Set.Apparent.Time().Equal(wantSet) {
+		t.Fatalf("Sun.Set.Apparent = %v, want %v", ann.Sun.Set.Apparent.Time(), wantSet)
+	}
+
+	if ann.Timezone == nil || ann.Timezone.Name != "Europe/Brussels" {
+		t.Fatalf("unexpected Timezone: %+v", ann.Timezone)
+	}
+	if ann.Currency == nil || ann.Currency.ISOCode != "EUR" {
+		t.Fatalf("unexpected Currency: %+v", ann.Currency)
+	}
+	if ann.CallingCode != 32 {
+		t.Fatalf("CallingCode = %d, want 32", ann.CallingCode)
+	}
+}
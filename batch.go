@@ -0,0 +1,117 @@
+package opencagedata
+
+import (
+	"context"
+	"sync"
+)
+
+// BatchResult pairs a query from GeocodeBatch with its result or error.
+type BatchResult struct {
+	Query  string
+	Result *GeocodeResult
+	Err    error
+}
+
+// GeocodeBatch geocodes every query in queries using up to concurrency
+// workers. Raising concurrency widens parallelism for slow network
+// round-trips without ever exceeding OpenCage's per-second limit: every
+// worker still serializes through the same lock/sleep GeocodeContext already
+// uses, so the rate limit is respected regardless of how many workers are
+// running.
+//
+// Results are returned in the same order as queries.
+func (g *Geocoder) GeocodeBatch(ctx context.Context, queries []string, params *GeocodeParams, concurrency int) ([]BatchResult, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]BatchResult, len(queries))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				result, err := g.GeocodeContext(ctx, queries[i], params)
+				results[i] = BatchResult{Query: queries[i], Result: result, Err: err}
+			}
+		}()
+	}
+
+	for i := range queries {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			close(jobs)
+			wg.Wait()
+
+			// i, and everything after it, was never handed to a worker, so
+			// results[i:] would otherwise be left at their zero value -
+			// indistinguishable from a successful, empty response. Stamp
+			// ctx.Err() into them explicitly; anything already dispatched
+			// was filled in by GeocodeContext's own cancellation check.
+			err := ctx.Err()
+			for j := i; j < len(queries); j++ {
+				results[j] = BatchResult{Query: queries[j], Err: err}
+			}
+			return results, err
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results, nil
+}
+
+// GeocodeBatchStream is GeocodeBatch for pipelines: it streams a BatchResult
+// for each query as soon as that query completes, in no particular order,
+// and closes the channel once every query has been geocoded or ctx is
+// cancelled.
+//
+// If the caller stops ranging over the returned channel before it's
+// exhausted without cancelling ctx, the workers still waiting to send their
+// remaining results block on out<- forever: cancel ctx if you need to stop
+// consuming early.
+func (g *Geocoder) GeocodeBatchStream(ctx context.Context, queries []string, params *GeocodeParams, concurrency int) <-chan BatchResult {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	out := make(chan BatchResult)
+	jobs := make(chan string)
+
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for query := range jobs {
+				result, err := g.GeocodeContext(ctx, query, params)
+				select {
+				case out <- BatchResult{Query: query, Result: result, Err: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		for _, query := range queries {
+			select {
+			case jobs <- query:
+			case <-ctx.Done():
+			}
+			if ctx.Err() != nil {
+				break
+			}
+		}
+		close(jobs)
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
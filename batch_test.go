@@ -0,0 +1,248 @@
+package opencagedata
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGeocodeBatchPreservesOrder(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status": map[string]interface{}{"code": 200, "message": "OK"},
+			"rate":   map[string]interface{}{"limit": 2500, "remaining": 2499, "reset": time.Now().Add(time.Hour).Unix()},
+			"results": []map[string]interface{}{
+				{"formatted": q},
+			},
+		})
+	}))
+	defer srv.Close()
+
+	g := &Geocoder{Key: "test", BaseURL: srv.URL + "/", DisableRateLimitSleep: true}
+
+	queries := []string{"a", "b", "c", "d"}
+	results, err := g.GeocodeBatch(context.Background(), queries, nil, 3)
+	if err != nil {
+		t.Fatalf("GeocodeBatch returned error: %v", err)
+	}
+	for i, r := range results {
+		if r.Err != nil {
+			t.Fatalf("query %q: %v", queries[i], r.Err)
+		}
+		if got := r.Result.Results[0].Formatted; got != queries[i] {
+			t.Fatalf("result %d: got %q, want %q", i, got, queries[i])
+		}
+	}
+}
+
+// TestGeocodeBatchOverlapsRequests guards against the rate-limit lock being
+// held across the HTTP round-trip, which would serialize every worker
+// behind it regardless of concurrency.
+func TestGeocodeBatchOverlapsRequests(t *testing.T) {
+	const sleepPerRequest = 100 * time.Millisecond
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(sleepPerRequest)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status": map[string]interface{}{"code": 200, "message": "OK"},
+			"rate":   map[string]interface{}{"limit": 2500, "remaining": 2499, "reset": time.Now().Add(time.Hour).Unix()},
+		})
+	}))
+	defer srv.Close()
+
+	g := &Geocoder{Key: "test", BaseURL: srv.URL + "/", DisableRateLimitSleep: true}
+
+	queries := make([]string, 10)
+	for i := range queries {
+		queries[i] = fmt.Sprintf("q%d", i)
+	}
+
+	start := time.Now()
+	results, err := g.GeocodeBatch(context.Background(), queries, nil, 10)
+	elapsed := time.Since(start)
+	if err != nil {
+		t.Fatalf("GeocodeBatch returned error: %v", err)
+	}
+	for i, r := range results {
+		if r.Err != nil {
+			t.Fatalf("query %d: %v", i, r.Err)
+		}
+	}
+
+	// 10 fully-serialized requests would take ~1s; genuine overlap should
+	// finish in well under half of that.
+	if elapsed > 5*sleepPerRequest {
+		t.Fatalf("GeocodeBatch took %v for 10 concurrent requests, want well under %v", elapsed, 5*sleepPerRequest)
+	}
+}
+
+// TestGeocodeBatchSerializesThroughRateLimitReservation guards against the
+// regression where every worker reads the same stale g.sleep and fires at
+// once instead of reserving a slot: it seeds a rate-limit window that has
+// already elapsed (the common real case - batch start, or right after a
+// quota reset) and checks that concurrent workers still land their
+// requests spaced apart by the known per-request interval, not all at once.
+func TestGeocodeBatchSerializesThroughRateLimitReservation(t *testing.T) {
+	const interval = 50 * time.Millisecond
+
+	var mu sync.Mutex
+	var times []time.Time
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		times = append(times, time.Now())
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status": map[string]interface{}{"code": 200, "message": "OK"},
+			"rate":   map[string]interface{}{"limit": 1, "remaining": 0, "reset": time.Now().Add(time.Hour).Unix()},
+		})
+	}))
+	defer srv.Close()
+
+	g := &Geocoder{
+		Key:      "test",
+		BaseURL:  srv.URL + "/",
+		sleep:    time.Now().Add(-time.Second),
+		interval: interval,
+	}
+
+	queries := make([]string, 5)
+	for i := range queries {
+		queries[i] = fmt.Sprintf("q%d", i)
+	}
+
+	results, err := g.GeocodeBatch(context.Background(), queries, nil, len(queries))
+	if err != nil {
+		t.Fatalf("GeocodeBatch returned error: %v", err)
+	}
+	for i, r := range results {
+		if r.Err != nil {
+			t.Fatalf("query %d: %v", i, r.Err)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(times) != len(queries) {
+		t.Fatalf("expected %d requests, got %d", len(queries), len(times))
+	}
+	for i := 1; i < len(times); i++ {
+		if gap := times[i].Sub(times[i-1]); gap < interval/2 {
+			t.Fatalf("requests %d and %d fired %v apart, want at least ~%v: workers raced through the reservation instead of serializing", i-1, i, gap, interval)
+		}
+	}
+}
+
+// TestGeocodeBatchStream guards against results going missing or queries
+// being dropped as they're streamed back in completion order.
+func TestGeocodeBatchStream(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status": map[string]interface{}{"code": 200, "message": "OK"},
+			"rate":   map[string]interface{}{"limit": 2500, "remaining": 2499, "reset": time.Now().Add(time.Hour).Unix()},
+			"results": []map[string]interface{}{
+				{"formatted": q},
+			},
+		})
+	}))
+	defer srv.Close()
+
+	g := &Geocoder{Key: "test", BaseURL: srv.URL + "/", DisableRateLimitSleep: true}
+
+	queries := []string{"a", "b", "c"}
+	seen := make(map[string]bool)
+	for r := range g.GeocodeBatchStream(context.Background(), queries, nil, 2) {
+		if r.Err != nil {
+			t.Fatalf("query %q: %v", r.Query, r.Err)
+		}
+		seen[r.Result.Results[0].Formatted] = true
+	}
+	for _, q := range queries {
+		if !seen[q] {
+			t.Fatalf("missing result for query %q", q)
+		}
+	}
+}
+
+// TestGeocodeBatchStreamStopsOnCancel guards against GeocodeBatchStream
+// hanging, or leaking its worker goroutines, when ctx is cancelled mid-stream.
+func TestGeocodeBatchStreamStopsOnCancel(t *testing.T) {
+	block := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-block
+	}))
+	defer srv.Close()
+	defer close(block)
+
+	g := &Geocoder{Key: "test", BaseURL: srv.URL + "/", DisableRateLimitSleep: true}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	queries := make([]string, 10)
+	for i := range queries {
+		queries[i] = fmt.Sprintf("q%d", i)
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		for range g.GeocodeBatchStream(ctx, queries, nil, 2) {
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("GeocodeBatchStream did not close its channel after ctx was cancelled")
+	}
+}
+
+// TestGeocodeBatchCancelledFillsRemainder guards against results[i] being
+// left at its ambiguous zero value (query="" result=nil err=nil) for queries
+// that were never dispatched before ctx was cancelled.
+func TestGeocodeBatchCancelledFillsRemainder(t *testing.T) {
+	block := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-block
+	}))
+	defer srv.Close()
+	defer close(block)
+
+	g := &Geocoder{Key: "test", BaseURL: srv.URL + "/", DisableRateLimitSleep: true}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	queries := make([]string, 10)
+	for i := range queries {
+		queries[i] = fmt.Sprintf("q%d", i)
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	results, err := g.GeocodeBatch(ctx, queries, nil, 2)
+	if err == nil {
+		t.Fatal("expected an error from cancellation")
+	}
+	for i, r := range results {
+		if r.Result == nil && r.Err == nil {
+			t.Fatalf("result %d (query %q) is an ambiguous zero value, want a stamped error", i, r.Query)
+		}
+	}
+}
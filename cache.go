@@ -0,0 +1,78 @@
+package opencagedata
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Cache is consulted by Geocode/GeocodeContext before a request is made, and
+// populated with successful results afterwards. Implementations must be
+// safe for concurrent use. See the lru and fs subpackages for ready-made
+// in-memory and filesystem-backed implementations.
+type Cache interface {
+	// Get returns the cached result for key, if any. The second return
+	// value is false if there is no entry, or if it has expired.
+	Get(key string) (*GeocodeResult, bool)
+
+	// Set stores result under key for ttl. A zero ttl means the entry never
+	// expires.
+	Set(key string, result *GeocodeResult, ttl time.Duration)
+}
+
+// defaultCacheTTL is used when Geocoder.CacheTTL is unset: OpenCage results
+// are stable, so a day-long TTL is a safe default.
+const defaultCacheTTL = 24 * time.Hour
+
+func (g *Geocoder) cacheTTL() time.Duration {
+	if g.CacheTTL != 0 {
+		return g.CacheTTL
+	}
+	return defaultCacheTTL
+}
+
+// cacheKey canonicalizes a query and its params into a single string so that
+// equivalent requests share a cache entry.
+func cacheKey(query string, params *GeocodeParams) string {
+	var b strings.Builder
+	b.WriteString(query)
+	if params != nil && *params != (GeocodeParams{}) {
+		fmt.Fprintf(&b, "|countrycode=%s|limit=%d|min_confidence=%d|no_annotations=%t|no_dedupe=%t|no_record=%t|language=%s|add_request=%t|abbrv=%t|pretty=%t",
+			strings.ToLower(params.CountryCode),
+			params.Limit,
+			params.MinConfidence,
+			params.NoAnnotations,
+			params.NoDedupe,
+			params.NoRecord,
+			params.Language,
+			params.AddRequest,
+			params.Abbreviate,
+			params.Pretty,
+		)
+		if params.Bounds != nil {
+			fmt.Fprintf(&b, "|bounds=%v,%v,%v,%v", params.Bounds.West, params.Bounds.South, params.Bounds.East, params.Bounds.North)
+		}
+	}
+	return b.String()
+}
+
+// Prefetch warms the cache for queries in the background so that later,
+// latency-sensitive callers hit the cache instead of the network. It returns
+// immediately; prefetch requests still go through the normal rate-limit
+// lock, so they interleave with real requests rather than starving them,
+// and any errors are discarded since there's no caller around to receive
+// them. Prefetch is a no-op if no Cache is configured.
+func (g *Geocoder) Prefetch(queries []string) {
+	if g.Cache == nil {
+		return
+	}
+
+	go func() {
+		for _, query := range queries {
+			if _, ok := g.Cache.Get(cacheKey(query, nil)); ok {
+				continue
+			}
+			g.Geocode(query, nil)
+		}
+	}()
+}
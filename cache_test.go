@@ -0,0 +1,82 @@
+package opencagedata
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type stubCache struct {
+	mu sync.Mutex
+	m  map[string]*GeocodeResult
+}
+
+func newStubCache() *stubCache {
+	return &stubCache{m: make(map[string]*GeocodeResult)}
+}
+
+func (c *stubCache) Get(key string) (*GeocodeResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	r, ok := c.m[key]
+	return r, ok
+}
+
+func (c *stubCache) Set(key string, result *GeocodeResult, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.m[key] = result
+}
+
+func okResponse(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status": map[string]interface{}{"code": 200, "message": "OK"},
+		"rate":   map[string]interface{}{"limit": 2500, "remaining": 2499, "reset": time.Now().Add(time.Hour).Unix()},
+	})
+}
+
+func TestPrefetchWarmsCache(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(okResponse))
+	defer srv.Close()
+
+	cache := newStubCache()
+	g := &Geocoder{Key: "test", BaseURL: srv.URL + "/", DisableRateLimitSleep: true, Cache: cache}
+
+	g.Prefetch([]string{"a", "b"})
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		_, a := cache.Get(cacheKey("a", nil))
+		_, b := cache.Get(cacheKey("b", nil))
+		if a && b {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("Prefetch did not warm the cache within 1s")
+}
+
+func TestPrefetchSkipsAlreadyCachedQueries(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		okResponse(w, r)
+	}))
+	defer srv.Close()
+
+	cache := newStubCache()
+	cache.Set(cacheKey("a", nil), &GeocodeResult{}, time.Minute)
+
+	g := &Geocoder{Key: "test", BaseURL: srv.URL + "/", DisableRateLimitSleep: true, Cache: cache}
+	g.Prefetch([]string{"a"})
+
+	time.Sleep(50 * time.Millisecond)
+	if got := atomic.LoadInt32(&calls); got != 0 {
+		t.Fatalf("expected no request for an already-cached query, got %d", got)
+	}
+}
@@ -0,0 +1,92 @@
+// Package fs provides a filesystem-backed opencagedata.Cache, storing each
+// entry as a JSON file so it survives process restarts.
+package fs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/JessicaCGlenn/opencagedata"
+)
+
+// Cache is a filesystem-backed opencagedata.Cache rooted at Dir. The zero
+// value is not usable; use New.
+type Cache struct {
+	Dir string
+}
+
+var _ opencagedata.Cache = (*Cache)(nil)
+
+// New returns a Cache that stores entries under dir, creating it if it
+// doesn't already exist.
+func New(dir string) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &Cache{Dir: dir}, nil
+}
+
+type record struct {
+	Result    *opencagedata.GeocodeResult `json:"result"`
+	ExpiresAt time.Time                   `json:"expires_at"`
+}
+
+func (c *Cache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.Dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (c *Cache) Get(key string) (*opencagedata.GeocodeResult, bool) {
+	data, err := ioutil.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var rec record
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, false
+	}
+	if !rec.ExpiresAt.IsZero() && time.Now().After(rec.ExpiresAt) {
+		os.Remove(c.path(key))
+		return nil, false
+	}
+
+	return rec.Result, true
+}
+
+// Set writes the entry as a temp file and renames it into place, so a
+// concurrent Get never observes a partially-written file: rename is atomic,
+// and readers only ever see the file before or after the swap, never a
+// torn write.
+func (c *Cache) Set(key string, result *opencagedata.GeocodeResult, ttl time.Duration) {
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	data, err := json.Marshal(record{Result: result, ExpiresAt: expiresAt})
+	if err != nil {
+		return
+	}
+
+	tmp, err := ioutil.TempFile(c.Dir, ".tmp-*")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return
+	}
+	if err := tmp.Close(); err != nil {
+		return
+	}
+
+	os.Rename(tmp.Name(), c.path(key))
+}
@@ -0,0 +1,33 @@
+package fs
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/JessicaCGlenn/opencagedata"
+)
+
+// TestCacheConcurrentSetDoesNotCorrupt guards against Set writing in place:
+// a Get racing a Set on the same key should never observe a torn write.
+func TestCacheConcurrentSetDoesNotCorrupt(t *testing.T) {
+	dir := t.TempDir()
+	c, err := New(dir)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	const n = 20
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c.Set("key", &opencagedata.GeocodeResult{}, time.Minute)
+			if _, ok := c.Get("key"); !ok {
+				t.Errorf("Get after Set returned false (goroutine %d)", i)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
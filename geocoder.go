@@ -29,10 +29,12 @@ Extra options can be passed as well:
 package opencagedata
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -49,8 +51,31 @@ type Geocoder struct {
 	// This library will sleep automatically to avoid hitting the rate limit.
 	DisableRateLimitSleep bool
 
-	lock  sync.Mutex
-	sleep time.Time
+	// HTTPClient is used to make requests. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+
+	// UserAgent, if set, is sent with every request.
+	UserAgent string
+
+	// BaseURL overrides the OpenCage endpoint. Defaults to endpoint; mainly
+	// useful for pointing at a fake server in tests.
+	BaseURL string
+
+	// MaxRetries is how many times a request is retried after a 429/5xx HTTP
+	// response or a 402/429 status.code from OpenCage, backing off between
+	// attempts. Zero (the default) disables retrying.
+	MaxRetries int
+
+	// Cache, if set, is consulted before every request and populated with
+	// successful results. See the Cache interface for implementations.
+	Cache Cache
+
+	// CacheTTL is how long a cached result stays fresh. Defaults to 24h.
+	CacheTTL time.Duration
+
+	lock     sync.Mutex
+	sleep    time.Time
+	interval time.Duration
 }
 
 type GeocodeBounds struct {
@@ -91,9 +116,11 @@ type GeocodeResult struct {
 }
 
 type GeocodeResultItem struct {
-	Confidence int      `json:"confidence"`
-	Formatted  string   `json:"formatted"`
-	Geometry   Geometry `json:"geometry"`
+	Confidence  int         `json:"confidence"`
+	Formatted   string      `json:"formatted"`
+	Geometry    Geometry    `json:"geometry"`
+	Components  Components  `json:"components"`
+	Annotations Annotations `json:"annotations"`
 
 	Bounds struct {
 		NorthEast Geometry `json:"northeast"`
@@ -112,7 +139,18 @@ type GeocodeError struct {
 }
 
 func (err *GeocodeError) Error() string {
-	return fmt.Sprintf("%s: %s", err.Result.Status.Code, err.Result.Status.Message)
+	return fmt.Sprintf("%d: %s", err.Result.Status.Code, err.Result.Status.Message)
+}
+
+// HTTPError is returned when retries are exhausted on an HTTP 429/5xx and
+// the response body was never decoded as OpenCage JSON, since it may not
+// be one (e.g. a proxy's own error page rather than anything OpenCage sent).
+type HTTPError struct {
+	StatusCode int
+}
+
+func (err *HTTPError) Error() string {
+	return fmt.Sprintf("opencagedata: giving up after HTTP %d", err.StatusCode)
 }
 
 func NewGeocoder(key string) *Geocoder {
@@ -125,43 +163,221 @@ func NewGeocoder(key string) *Geocoder {
 //
 // The params parameter is optional, feel free to pass nil when no specific options are needed.
 func (g *Geocoder) Geocode(query string, params *GeocodeParams) (*GeocodeResult, error) {
+	return g.GeocodeContext(context.Background(), query, params)
+}
+
+// ReverseGeocode looks up the address at a given coordinate.
+//
+// It formats the query as OpenCage expects ("lat,lng") and otherwise behaves
+// exactly like Geocode, including sharing its rate-limit sleep.
+func (g *Geocoder) ReverseGeocode(lat, lng float64, params *GeocodeParams) (*GeocodeResult, error) {
+	return g.Geocode(latLngQuery(lat, lng), params)
+}
+
+// GeocodeContext is Geocode, but cancellable via ctx. Unlike Geocode, which
+// sleeps through any rate-limit delay unconditionally, GeocodeContext returns
+// ctx.Err() as soon as ctx is cancelled, even mid-sleep or mid-retry.
+//
+// Rate limiting works by reservation: rateLimitDelay claims the caller's own
+// slot in g.sleep, under g.lock, before returning - so a second caller that
+// reads g.sleep while the first is still sleeping or mid-request is gated
+// against that reservation, not the last-completed response. The lock is
+// held only long enough to claim the slot, never across the HTTP
+// round-trip itself, so concurrent callers (e.g. GeocodeBatch workers)
+// still have their requests genuinely overlap in flight rather than
+// blocking on one another's network round-trip - they just can't jump each
+// other's reserved slot.
+func (g *Geocoder) GeocodeContext(ctx context.Context, query string, params *GeocodeParams) (*GeocodeResult, error) {
+	key := cacheKey(query, params)
+	if g.Cache != nil {
+		if cached, ok := g.Cache.Get(key); ok {
+			return cached, nil
+		}
+	}
+
+	if err := g.wait(ctx, g.rateLimitDelay()); err != nil {
+		return nil, err
+	}
+
+	result, err := g.doGeocode(ctx, query, params, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	g.setRateLimitSleep(result)
+	if g.Cache != nil {
+		g.Cache.Set(key, result, g.cacheTTL())
+	}
+	return result, nil
+}
+
+// rateLimitDelay reserves the caller's own slot in the request ordering and
+// returns how long it must wait before firing its request. It advances
+// g.sleep by g.interval, the last known gap between requests, before
+// releasing the lock, so the next caller in is gated against this
+// reservation rather than racing it to read the pre-reservation g.sleep.
+func (g *Geocoder) rateLimitDelay() time.Duration {
 	g.lock.Lock()
 	defer g.lock.Unlock()
 
-	sleep := g.sleep.Sub(time.Now())
-	if sleep > 0 {
-		time.Sleep(sleep)
+	now := time.Now()
+	start := g.sleep
+	if start.Before(now) {
+		start = now
+	}
+	g.sleep = start.Add(g.interval)
+	return start.Sub(now)
+}
+
+// setRateLimitSleep records the gap to leave before the next request, based
+// on the rate info in result. It only updates g.interval, never g.sleep
+// directly: slots are already reserved by rateLimitDelay as requests go
+// out, and a response arriving out of order must not clobber another
+// worker's reservation.
+func (g *Geocoder) setRateLimitSleep(result *GeocodeResult) {
+	if g.DisableRateLimitSleep {
+		return
 	}
+	delay := nextRequestDelay(result.Rate.Remaining, result.Rate.Reset)
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.interval = delay
+}
+
+// ReverseGeocodeContext is ReverseGeocode, but cancellable via ctx.
+func (g *Geocoder) ReverseGeocodeContext(ctx context.Context, lat, lng float64, params *GeocodeParams) (*GeocodeResult, error) {
+	return g.GeocodeContext(ctx, latLngQuery(lat, lng), params)
+}
+
+// latLngQuery formats a coordinate the way OpenCage expects ("lat,lng").
+// strconv.FormatFloat is used instead of fmt's "%v" because the latter
+// switches to scientific notation for small magnitudes (e.g. 1e-05), which
+// OpenCage does not accept as a coordinate.
+func latLngQuery(lat, lng float64) string {
+	return strconv.FormatFloat(lat, 'f', -1, 64) + "," + strconv.FormatFloat(lng, 'f', -1, 64)
+}
 
-	u := g.geocodeUrl(query, params)
-	resp, err := http.Get(u)
+func (g *Geocoder) doGeocode(ctx context.Context, query string, params *GeocodeParams, attempt int) (*GeocodeResult, error) {
+	req, err := http.NewRequest("GET", g.geocodeUrl(query, params), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if g.UserAgent != "" {
+		req.Header.Set("User-Agent", g.UserAgent)
+	}
+
+	resp, err := g.httpClient().Do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+		if attempt < g.MaxRetries {
+			if err := g.wait(ctx, retryDelayFromHeader(resp.Header, attempt)); err != nil {
+				return nil, err
+			}
+			return g.doGeocode(ctx, query, params, attempt+1)
+		}
+		return nil, &HTTPError{StatusCode: resp.StatusCode}
+	}
+
 	var result GeocodeResult
-	err = json.NewDecoder(resp.Body).Decode(&result)
-	if err != nil {
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
 		return nil, err
 	}
+
+	if (result.Status.Code == 402 || result.Status.Code == 429) && attempt < g.MaxRetries {
+		if err := g.wait(ctx, retryDelayFromRate(result.Rate.Reset, attempt)); err != nil {
+			return nil, err
+		}
+		return g.doGeocode(ctx, query, params, attempt+1)
+	}
+
 	if result.Status.Code != 200 {
 		return nil, &GeocodeError{Result: &result}
 	}
 
-	if !g.DisableRateLimitSleep {
-		reset := time.Unix(result.Rate.Reset, 0)
-		untilReset := reset.Sub(time.Now())
-		delay := time.Duration(float64(untilReset+1) / (float64(result.Rate.Remaining) + 1))
-		g.sleep = time.Now().Add(delay)
+	return &result, nil
+}
+
+// nextRequestDelay spreads the calls OpenCage says remain in the current
+// window evenly across what's left of it, rather than greedily front-loading
+// them and then stalling once the quota runs out.
+func nextRequestDelay(remaining int, reset int64) time.Duration {
+	untilReset := time.Until(time.Unix(reset, 0))
+	if untilReset <= 0 {
+		return 0
+	}
+	if remaining <= 0 {
+		return untilReset
 	}
+	return untilReset / time.Duration(remaining)
+}
 
-	return &result, nil
+// wait blocks for d, or until ctx is cancelled, whichever comes first.
+func (g *Geocoder) wait(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (g *Geocoder) httpClient() *http.Client {
+	if g.HTTPClient != nil {
+		return g.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+func (g *Geocoder) baseURL() string {
+	if g.BaseURL != "" {
+		return g.BaseURL
+	}
+	return endpoint
+}
+
+// retryDelayFromHeader computes a backoff delay for an HTTP-level 429/5xx,
+// preferring the X-RateLimit-Reset header when present.
+func retryDelayFromHeader(header http.Header, attempt int) time.Duration {
+	if reset := header.Get("X-RateLimit-Reset"); reset != "" {
+		if sec, err := strconv.ParseInt(reset, 10, 64); err == nil {
+			if d := time.Until(time.Unix(sec, 0)); d > 0 {
+				return d
+			}
+		}
+	}
+	return backoffDelay(attempt)
+}
+
+// retryDelayFromRate computes a backoff delay for a 402/429 status.code,
+// preferring the rate.reset field when present.
+func retryDelayFromRate(reset int64, attempt int) time.Duration {
+	if reset != 0 {
+		if d := time.Until(time.Unix(reset, 0)); d > 0 {
+			return d
+		}
+	}
+	return backoffDelay(attempt)
+}
+
+func backoffDelay(attempt int) time.Duration {
+	return (1 << uint(attempt)) * time.Second
 }
 
 // Split out for testing purposes
 func (g *Geocoder) geocodeUrl(query string, params *GeocodeParams) string {
-	u, _ := url.Parse(endpoint)
+	u, _ := url.Parse(g.baseURL())
 	u.Path += "json"
 
 	q := u.Query()
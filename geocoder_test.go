@@ -0,0 +1,134 @@
+package opencagedata
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGeocodeRetriesOn429(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("X-RateLimit-Reset", fmt.Sprintf("%d", time.Now().Add(10*time.Millisecond).Unix()))
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status": map[string]interface{}{"code": 200, "message": "OK"},
+			"rate":   map[string]interface{}{"limit": 2500, "remaining": 2499, "reset": time.Now().Add(time.Hour).Unix()},
+			"results": []map[string]interface{}{
+				{"formatted": "Test Result"},
+			},
+		})
+	}))
+	defer srv.Close()
+
+	g := &Geocoder{
+		Key:        "test",
+		BaseURL:    srv.URL + "/",
+		MaxRetries: 2,
+	}
+
+	result, err := g.GeocodeContext(context.Background(), "somewhere", nil)
+	if err != nil {
+		t.Fatalf("GeocodeContext returned error: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 requests (1 retry), got %d", calls)
+	}
+	if len(result.Results) != 1 || result.Results[0].Formatted != "Test Result" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+}
+
+func TestGeocodeGivesUpAfterMaxRetries(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	g := &Geocoder{
+		Key:        "test",
+		BaseURL:    srv.URL + "/",
+		MaxRetries: 2,
+	}
+
+	_, err := g.GeocodeContext(context.Background(), "somewhere", nil)
+	httpErr, ok := err.(*HTTPError)
+	if !ok {
+		t.Fatalf("expected *HTTPError once retries are exhausted, got %T: %v", err, err)
+	}
+	if httpErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected status %d, got %d", http.StatusTooManyRequests, httpErr.StatusCode)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 requests (1 initial + 2 retries), got %d", calls)
+	}
+}
+
+// TestGeocodeGivesUpWithNonJSONBody guards against a proxy's own error page
+// (e.g. an HTML 503) being handed to json.Decode and surfacing as an opaque
+// parse error instead of a clear, typed HTTPError once retries are spent.
+func TestGeocodeGivesUpWithNonJSONBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte("<html><body>503 Service Unavailable</body></html>"))
+	}))
+	defer srv.Close()
+
+	g := &Geocoder{Key: "test", BaseURL: srv.URL + "/"}
+
+	_, err := g.GeocodeContext(context.Background(), "somewhere", nil)
+	httpErr, ok := err.(*HTTPError)
+	if !ok {
+		t.Fatalf("expected *HTTPError, got %T: %v", err, err)
+	}
+	if httpErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected status %d, got %d", http.StatusServiceUnavailable, httpErr.StatusCode)
+	}
+}
+
+func TestGeocodeContextCancelledDuringRateLimitSleep(t *testing.T) {
+	g := &Geocoder{sleep: time.Now().Add(time.Hour)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := g.GeocodeContext(ctx, "somewhere", nil)
+	if err != context.DeadlineExceeded {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestReverseGeocodeFormatsSmallCoordinates(t *testing.T) {
+	var gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.Query().Get("q")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status": map[string]interface{}{"code": 200, "message": "OK"},
+			"rate":   map[string]interface{}{"limit": 2500, "remaining": 2499, "reset": time.Now().Add(time.Hour).Unix()},
+		})
+	}))
+	defer srv.Close()
+
+	g := &Geocoder{Key: "test", BaseURL: srv.URL + "/", DisableRateLimitSleep: true}
+	if _, err := g.ReverseGeocode(0.00001, 0.00002, nil); err != nil {
+		t.Fatalf("ReverseGeocode returned error: %v", err)
+	}
+
+	if want := "0.00001,0.00002"; gotQuery != want {
+		t.Fatalf("query = %q, want %q", gotQuery, want)
+	}
+}
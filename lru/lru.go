@@ -0,0 +1,92 @@
+// Package lru provides an in-memory, size-bounded opencagedata.Cache.
+package lru
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/JessicaCGlenn/opencagedata"
+)
+
+type entry struct {
+	key       string
+	result    *opencagedata.GeocodeResult
+	expiresAt time.Time
+}
+
+// Cache is an in-memory opencagedata.Cache that evicts the least recently
+// used entry once it grows past Capacity. The zero value is not usable; use
+// New.
+type Cache struct {
+	Capacity int
+
+	mu    sync.Mutex
+	items map[string]*list.Element
+	order *list.List
+}
+
+var _ opencagedata.Cache = (*Cache)(nil)
+
+// New returns an in-memory LRU cache holding up to capacity entries. A
+// capacity of zero means unbounded.
+func New(capacity int) *Cache {
+	return &Cache{
+		Capacity: capacity,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (c *Cache) Get(key string) (*opencagedata.GeocodeResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	e := el.Value.(*entry)
+	if !e.expiresAt.IsZero() && time.Now().After(e.expiresAt) {
+		c.order.Remove(el)
+		delete(c.items, key)
+		return nil, false
+	}
+
+	c.order.MoveToFront(el)
+	return e.result, true
+}
+
+func (c *Cache) Set(key string, result *opencagedata.GeocodeResult, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	if el, ok := c.items[key]; ok {
+		e := el.Value.(*entry)
+		e.result = result
+		e.expiresAt = expiresAt
+		c.order.MoveToFront(el)
+		return
+	}
+
+	el := c.order.PushFront(&entry{key: key, result: result, expiresAt: expiresAt})
+	c.items[key] = el
+
+	if c.Capacity <= 0 {
+		return
+	}
+	for c.order.Len() > c.Capacity {
+		oldest := c.order.Back()
+		if oldest == nil {
+			break
+		}
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*entry).key)
+	}
+}
@@ -0,0 +1,54 @@
+package lru
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/JessicaCGlenn/opencagedata"
+)
+
+func TestCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := New(2)
+	c.Set("a", &opencagedata.GeocodeResult{}, 0)
+	c.Set("b", &opencagedata.GeocodeResult{}, 0)
+	c.Get("a") // touch a, making b the least recently used
+	c.Set("c", &opencagedata.GeocodeResult{}, 0)
+
+	if _, ok := c.Get("b"); ok {
+		t.Fatal("expected b to be evicted")
+	}
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("expected a to still be cached")
+	}
+	if _, ok := c.Get("c"); !ok {
+		t.Fatal("expected c to be cached")
+	}
+}
+
+func TestCacheExpiresEntries(t *testing.T) {
+	c := New(0)
+	c.Set("a", &opencagedata.GeocodeResult{}, time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("expected expired entry to be evicted")
+	}
+}
+
+func TestCacheConcurrentAccess(t *testing.T) {
+	c := New(16)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := fmt.Sprintf("key-%d", i%8)
+			c.Set(key, &opencagedata.GeocodeResult{}, time.Minute)
+			c.Get(key)
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,207 @@
+package opencagedata
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const nominatimDefaultBaseURL = "https://nominatim.openstreetmap.org"
+
+// nominatimMinInterval enforces OSM's "no more than 1 request per second"
+// usage policy: https://operations.osmfoundation.org/policies/nominatim/
+const nominatimMinInterval = time.Second
+
+// Nominatim is a Provider backed by the OpenStreetMap Nominatim geocoder. It
+// is free and keyless, but OSM's usage policy requires an identifying
+// User-Agent and caps usage at one request per second. This type enforces
+// the one-request-per-second cap itself and sends UserAgent when it is set;
+// an empty UserAgent is not rejected, and Go's default User-Agent is sent
+// instead.
+type Nominatim struct {
+	// BaseURL defaults to the public OSM-hosted instance; set it to point at
+	// a self-hosted Nominatim server instead.
+	BaseURL string
+
+	// UserAgent is sent on every request when non-empty; OSM's usage policy
+	// requires one.
+	UserAgent string
+
+	HTTPClient *http.Client
+
+	lock        sync.Mutex
+	lastRequest time.Time
+}
+
+func NewNominatim(userAgent string) *Nominatim {
+	return &Nominatim{UserAgent: userAgent}
+}
+
+func (n *Nominatim) baseURL() string {
+	if n.BaseURL != "" {
+		return n.BaseURL
+	}
+	return nominatimDefaultBaseURL
+}
+
+func (n *Nominatim) httpClient() *http.Client {
+	if n.HTTPClient != nil {
+		return n.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// throttle blocks until it's been at least nominatimMinInterval since the
+// last request, or ctx is cancelled, whichever comes first.
+func (n *Nominatim) throttle(ctx context.Context) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	sleep := n.lastRequest.Add(nominatimMinInterval).Sub(time.Now())
+	if sleep > 0 {
+		timer := time.NewTimer(sleep)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	n.lastRequest = time.Now()
+	return nil
+}
+
+type nominatimResult struct {
+	Lat         string `json:"lat"`
+	Lon         string `json:"lon"`
+	DisplayName string `json:"display_name"`
+	Address     struct {
+		Country     string `json:"country"`
+		CountryCode string `json:"country_code"`
+		State       string `json:"state"`
+		City        string `json:"city"`
+		Postcode    string `json:"postcode"`
+		Road        string `json:"road"`
+		HouseNumber string `json:"house_number"`
+	} `json:"address"`
+}
+
+// nominatimSingleResponse is what the /reverse endpoint returns for a single
+// coordinate: either a nominatimResult, or {"error": "Unable to geocode"}
+// when nothing matches.
+type nominatimSingleResponse struct {
+	nominatimResult
+	Error string `json:"error"`
+}
+
+func (s nominatimSingleResponse) isMatch() bool {
+	return s.Error == "" && s.DisplayName != ""
+}
+
+func (n *Nominatim) Geocode(query string, params *GeocodeParams) (*GeocodeResult, error) {
+	return n.GeocodeContext(context.Background(), query, params)
+}
+
+func (n *Nominatim) ReverseGeocode(lat, lng float64, params *GeocodeParams) (*GeocodeResult, error) {
+	return n.ReverseGeocodeContext(context.Background(), lat, lng, params)
+}
+
+func (n *Nominatim) GeocodeContext(ctx context.Context, query string, params *GeocodeParams) (*GeocodeResult, error) {
+	q := url.Values{}
+	q.Set("q", query)
+	q.Set("format", "json")
+	q.Set("addressdetails", "1")
+	if params != nil {
+		if params.Limit != 0 {
+			q.Set("limit", fmt.Sprintf("%v", params.Limit))
+		}
+		if params.CountryCode != "" {
+			q.Set("countrycodes", strings.ToLower(params.CountryCode))
+		}
+		if params.Language != "" {
+			q.Set("accept-language", params.Language)
+		}
+	}
+	return n.fetch(ctx, n.baseURL()+"/search?"+q.Encode())
+}
+
+func (n *Nominatim) ReverseGeocodeContext(ctx context.Context, lat, lng float64, params *GeocodeParams) (*GeocodeResult, error) {
+	q := url.Values{}
+	q.Set("lat", strconv.FormatFloat(lat, 'f', -1, 64))
+	q.Set("lon", strconv.FormatFloat(lng, 'f', -1, 64))
+	q.Set("format", "json")
+	q.Set("addressdetails", "1")
+	return n.fetch(ctx, n.baseURL()+"/reverse?"+q.Encode())
+}
+
+func (n *Nominatim) fetch(ctx context.Context, u string) (*GeocodeResult, error) {
+	if err := n.throttle(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if n.UserAgent != "" {
+		req.Header.Set("User-Agent", n.UserAgent)
+	}
+
+	resp, err := n.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// The search endpoint returns a JSON array, reverse a single object;
+	// normalize both into a slice.
+	var raw json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	var items []nominatimResult
+	if err := json.Unmarshal(raw, &items); err != nil {
+		var single nominatimSingleResponse
+		if err := json.Unmarshal(raw, &single); err != nil {
+			return nil, err
+		}
+		// No match (e.g. {"error": "Unable to geocode"}) means zero results,
+		// not one bogus, all-zero-valued one.
+		if single.isMatch() {
+			items = []nominatimResult{single.nominatimResult}
+		}
+	}
+
+	result := &GeocodeResult{}
+	result.Status.Code = 200
+	for _, item := range items {
+		lat, _ := strconv.ParseFloat(item.Lat, 32)
+		lon, _ := strconv.ParseFloat(item.Lon, 32)
+		result.Results = append(result.Results, GeocodeResultItem{
+			Formatted: item.DisplayName,
+			Geometry: Geometry{
+				Latitude:  float32(lat),
+				Longitude: float32(lon),
+			},
+			Components: Components{
+				Country:     item.Address.Country,
+				CountryCode: item.Address.CountryCode,
+				State:       item.Address.State,
+				City:        item.Address.City,
+				Postcode:    item.Address.Postcode,
+				Road:        item.Address.Road,
+				HouseNumber: item.Address.HouseNumber,
+			},
+		})
+	}
+	return result, nil
+}
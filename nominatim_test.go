@@ -0,0 +1,85 @@
+package opencagedata
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNominatimGeocode(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ua := r.Header.Get("User-Agent"); ua != "test-agent" {
+			t.Errorf("User-Agent = %q, want test-agent", ua)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]map[string]interface{}{
+			{
+				"lat":          "50.88",
+				"lon":          "4.7",
+				"display_name": "Leuven, Belgium",
+				"address": map[string]interface{}{
+					"country":      "Belgium",
+					"country_code": "be",
+					"city":         "Leuven",
+				},
+			},
+		})
+	}))
+	defer srv.Close()
+
+	n := &Nominatim{BaseURL: srv.URL, UserAgent: "test-agent"}
+	result, err := n.Geocode("Leuven", nil)
+	if err != nil {
+		t.Fatalf("Geocode returned error: %v", err)
+	}
+	if len(result.Results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(result.Results))
+	}
+	if result.Results[0].Formatted != "Leuven, Belgium" {
+		t.Fatalf("unexpected result: %+v", result.Results[0])
+	}
+}
+
+// TestNominatimReverseGeocodeNoMatch guards against the /reverse "no match"
+// shape ({"error": "Unable to geocode"}) being mistaken for a single,
+// all-zero-valued result.
+func TestNominatimReverseGeocodeNoMatch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"error": "Unable to geocode"})
+	}))
+	defer srv.Close()
+
+	n := &Nominatim{BaseURL: srv.URL}
+	result, err := n.ReverseGeocodeContext(context.Background(), 0, 0, nil)
+	if err != nil {
+		t.Fatalf("ReverseGeocodeContext returned error: %v", err)
+	}
+	if len(result.Results) != 0 {
+		t.Fatalf("expected 0 results for a no-match response, got %d: %+v", len(result.Results), result.Results)
+	}
+}
+
+// TestNominatimThrottleRespectsContext guards against throttle ignoring ctx
+// cancellation while it waits out nominatimMinInterval.
+func TestNominatimThrottleRespectsContext(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]map[string]interface{}{})
+	}))
+	defer srv.Close()
+
+	n := &Nominatim{BaseURL: srv.URL}
+	if _, err := n.GeocodeContext(context.Background(), "a", nil); err != nil {
+		t.Fatalf("first GeocodeContext: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := n.GeocodeContext(ctx, "b", nil); err != context.DeadlineExceeded {
+		t.Fatalf("GeocodeContext error = %v, want context.DeadlineExceeded while waiting out the throttle", err)
+	}
+}
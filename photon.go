@@ -0,0 +1,111 @@
+package opencagedata
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const photonDefaultBaseURL = "https://photon.komoot.io"
+
+// Photon is a Provider backed by the free, keyless Photon geocoder
+// (https://photon.komoot.io), itself built on OpenStreetMap data.
+type Photon struct {
+	// BaseURL defaults to the public Komoot-hosted instance; set it to point
+	// at a self-hosted Photon server instead.
+	BaseURL string
+}
+
+func NewPhoton() *Photon {
+	return &Photon{}
+}
+
+func (p *Photon) baseURL() string {
+	if p.BaseURL != "" {
+		return p.BaseURL
+	}
+	return photonDefaultBaseURL
+}
+
+type photonResponse struct {
+	Features []struct {
+		Geometry struct {
+			Coordinates [2]float64 `json:"coordinates"`
+		} `json:"geometry"`
+		Properties struct {
+			Name        string `json:"name"`
+			Country     string `json:"country"`
+			State       string `json:"state"`
+			CountryCode string `json:"countrycode"`
+		} `json:"properties"`
+	} `json:"features"`
+}
+
+func (p *Photon) Geocode(query string, params *GeocodeParams) (*GeocodeResult, error) {
+	return p.GeocodeContext(context.Background(), query, params)
+}
+
+func (p *Photon) ReverseGeocode(lat, lng float64, params *GeocodeParams) (*GeocodeResult, error) {
+	return p.ReverseGeocodeContext(context.Background(), lat, lng, params)
+}
+
+func (p *Photon) GeocodeContext(ctx context.Context, query string, params *GeocodeParams) (*GeocodeResult, error) {
+	q := url.Values{}
+	q.Set("q", query)
+	if params != nil {
+		if params.Limit != 0 {
+			q.Set("limit", fmt.Sprintf("%v", params.Limit))
+		}
+		if params.Language != "" {
+			q.Set("lang", params.Language)
+		}
+	}
+	return p.fetch(ctx, p.baseURL()+"/api/?"+q.Encode())
+}
+
+func (p *Photon) ReverseGeocodeContext(ctx context.Context, lat, lng float64, params *GeocodeParams) (*GeocodeResult, error) {
+	q := url.Values{}
+	q.Set("lat", strconv.FormatFloat(lat, 'f', -1, 64))
+	q.Set("lon", strconv.FormatFloat(lng, 'f', -1, 64))
+	return p.fetch(ctx, p.baseURL()+"/reverse?"+q.Encode())
+}
+
+func (p *Photon) fetch(ctx context.Context, u string) (*GeocodeResult, error) {
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var parsed photonResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, err
+	}
+
+	result := &GeocodeResult{}
+	result.Status.Code = 200
+	for _, f := range parsed.Features {
+		result.Results = append(result.Results, GeocodeResultItem{
+			Formatted: f.Properties.Name,
+			Geometry: Geometry{
+				Latitude:  float32(f.Geometry.Coordinates[1]),
+				Longitude: float32(f.Geometry.Coordinates[0]),
+			},
+			Components: Components{
+				Country:     f.Properties.Country,
+				CountryCode: f.Properties.CountryCode,
+				State:       f.Properties.State,
+			},
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,71 @@
+package opencagedata
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPhotonGeocode(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/" {
+			t.Errorf("path = %q, want /api/", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"features": []map[string]interface{}{
+				{
+					"geometry": map[string]interface{}{"coordinates": []float64{4.7, 50.88}},
+					"properties": map[string]interface{}{
+						"name":        "Leuven",
+						"country":     "Belgium",
+						"state":       "Flanders",
+						"countrycode": "BE",
+					},
+				},
+			},
+		})
+	}))
+	defer srv.Close()
+
+	p := &Photon{BaseURL: srv.URL}
+	result, err := p.Geocode("Leuven", nil)
+	if err != nil {
+		t.Fatalf("Geocode returned error: %v", err)
+	}
+	if len(result.Results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(result.Results))
+	}
+
+	item := result.Results[0]
+	if item.Formatted != "Leuven" || item.Components.Country != "Belgium" || item.Components.CountryCode != "BE" {
+		t.Fatalf("unexpected result: %+v", item)
+	}
+	if item.Geometry.Longitude != 4.7 || item.Geometry.Latitude != 50.88 {
+		t.Fatalf("unexpected geometry: %+v", item.Geometry)
+	}
+}
+
+func TestPhotonReverseGeocodeContext(t *testing.T) {
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"features": []map[string]interface{}{}})
+	}))
+	defer srv.Close()
+
+	p := &Photon{BaseURL: srv.URL}
+	result, err := p.ReverseGeocodeContext(context.Background(), 50.88, 4.7, nil)
+	if err != nil {
+		t.Fatalf("ReverseGeocodeContext returned error: %v", err)
+	}
+	if gotPath != "/reverse" {
+		t.Fatalf("path = %q, want /reverse", gotPath)
+	}
+	if len(result.Results) != 0 {
+		t.Fatalf("expected 0 results, got %d", len(result.Results))
+	}
+}
@@ -0,0 +1,144 @@
+package opencagedata
+
+import (
+	"context"
+	"errors"
+)
+
+// Provider is implemented by anything that can turn a free-text query or a
+// coordinate pair into a GeocodeResult, with and without a context for
+// cancellation. Geocoder (OpenCage) is the primary implementation; Photon
+// and Nominatim are free, keyless fallbacks, and Chain/Race combine several
+// providers into one.
+type Provider interface {
+	Geocode(query string, params *GeocodeParams) (*GeocodeResult, error)
+	ReverseGeocode(lat, lng float64, params *GeocodeParams) (*GeocodeResult, error)
+	GeocodeContext(ctx context.Context, query string, params *GeocodeParams) (*GeocodeResult, error)
+	ReverseGeocodeContext(ctx context.Context, lat, lng float64, params *GeocodeParams) (*GeocodeResult, error)
+}
+
+var (
+	_ Provider = (*Geocoder)(nil)
+	_ Provider = (*Photon)(nil)
+	_ Provider = (*Nominatim)(nil)
+	_ Provider = (*Chain)(nil)
+	_ Provider = (*Race)(nil)
+)
+
+// ErrNoResults is returned by Chain and Race when every provider came back
+// with zero results and none returned an error. If any provider errored, the
+// last such error is returned instead.
+var ErrNoResults = errors.New("opencagedata: no provider returned a result")
+
+// Chain tries each Provider in order, falling through to the next one when a
+// provider errors or comes back with zero results. It's useful for keeping
+// OpenCage as the primary provider while degrading to a free provider like
+// Nominatim when no key is configured or the quota is exhausted.
+type Chain struct {
+	Providers []Provider
+}
+
+func NewChain(providers ...Provider) *Chain {
+	return &Chain{Providers: providers}
+}
+
+func (c *Chain) Geocode(query string, params *GeocodeParams) (*GeocodeResult, error) {
+	return c.GeocodeContext(context.Background(), query, params)
+}
+
+func (c *Chain) ReverseGeocode(lat, lng float64, params *GeocodeParams) (*GeocodeResult, error) {
+	return c.ReverseGeocodeContext(context.Background(), lat, lng, params)
+}
+
+func (c *Chain) GeocodeContext(ctx context.Context, query string, params *GeocodeParams) (*GeocodeResult, error) {
+	return chain(c.Providers, func(p Provider) (*GeocodeResult, error) {
+		return p.GeocodeContext(ctx, query, params)
+	})
+}
+
+func (c *Chain) ReverseGeocodeContext(ctx context.Context, lat, lng float64, params *GeocodeParams) (*GeocodeResult, error) {
+	return chain(c.Providers, func(p Provider) (*GeocodeResult, error) {
+		return p.ReverseGeocodeContext(ctx, lat, lng, params)
+	})
+}
+
+func chain(providers []Provider, call func(Provider) (*GeocodeResult, error)) (*GeocodeResult, error) {
+	var lastErr error
+	for _, p := range providers {
+		result, err := call(p)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if len(result.Results) == 0 {
+			continue
+		}
+		return result, nil
+	}
+	if lastErr != nil {
+		return nil, lastErr
+	}
+	return nil, ErrNoResults
+}
+
+// Race queries every Provider concurrently and returns the first successful,
+// non-empty result; the rest are left to finish and their results discarded.
+type Race struct {
+	Providers []Provider
+}
+
+func NewRace(providers ...Provider) *Race {
+	return &Race{Providers: providers}
+}
+
+func (r *Race) Geocode(query string, params *GeocodeParams) (*GeocodeResult, error) {
+	return r.GeocodeContext(context.Background(), query, params)
+}
+
+func (r *Race) ReverseGeocode(lat, lng float64, params *GeocodeParams) (*GeocodeResult, error) {
+	return r.ReverseGeocodeContext(context.Background(), lat, lng, params)
+}
+
+func (r *Race) GeocodeContext(ctx context.Context, query string, params *GeocodeParams) (*GeocodeResult, error) {
+	return race(r.Providers, func(p Provider) (*GeocodeResult, error) {
+		return p.GeocodeContext(ctx, query, params)
+	})
+}
+
+func (r *Race) ReverseGeocodeContext(ctx context.Context, lat, lng float64, params *GeocodeParams) (*GeocodeResult, error) {
+	return race(r.Providers, func(p Provider) (*GeocodeResult, error) {
+		return p.ReverseGeocodeContext(ctx, lat, lng, params)
+	})
+}
+
+type raceResult struct {
+	result *GeocodeResult
+	err    error
+}
+
+func race(providers []Provider, call func(Provider) (*GeocodeResult, error)) (*GeocodeResult, error) {
+	ch := make(chan raceResult, len(providers))
+	for _, p := range providers {
+		go func(p Provider) {
+			result, err := call(p)
+			ch <- raceResult{result, err}
+		}(p)
+	}
+
+	var lastErr error
+	for range providers {
+		res := <-ch
+		if res.err != nil {
+			lastErr = res.err
+			continue
+		}
+		if len(res.result.Results) == 0 {
+			continue
+		}
+		return res.result, nil
+	}
+	if lastErr != nil {
+		return nil, lastErr
+	}
+	return nil, ErrNoResults
+}
@@ -0,0 +1,103 @@
+package opencagedata
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type stubProvider struct {
+	result *GeocodeResult
+	err    error
+	calls  int
+}
+
+func (s *stubProvider) Geocode(query string, params *GeocodeParams) (*GeocodeResult, error) {
+	return s.GeocodeContext(context.Background(), query, params)
+}
+
+func (s *stubProvider) ReverseGeocode(lat, lng float64, params *GeocodeParams) (*GeocodeResult, error) {
+	return s.ReverseGeocodeContext(context.Background(), lat, lng, params)
+}
+
+func (s *stubProvider) GeocodeContext(ctx context.Context, query string, params *GeocodeParams) (*GeocodeResult, error) {
+	s.calls++
+	return s.result, s.err
+}
+
+func (s *stubProvider) ReverseGeocodeContext(ctx context.Context, lat, lng float64, params *GeocodeParams) (*GeocodeResult, error) {
+	return s.GeocodeContext(ctx, "", params)
+}
+
+var _ Provider = (*stubProvider)(nil)
+
+func found(formatted string) *GeocodeResult {
+	return &GeocodeResult{Results: []GeocodeResultItem{{Formatted: formatted}}}
+}
+
+func TestChainFallsThroughOnEmptyResult(t *testing.T) {
+	empty := &stubProvider{result: &GeocodeResult{}}
+	good := &stubProvider{result: found("found")}
+
+	c := NewChain(empty, good)
+	result, err := c.Geocode("query", nil)
+	if err != nil {
+		t.Fatalf("Geocode returned error: %v", err)
+	}
+	if empty.calls != 1 || good.calls != 1 {
+		t.Fatalf("expected both providers to be tried, got empty.calls=%d good.calls=%d", empty.calls, good.calls)
+	}
+	if len(result.Results) != 1 || result.Results[0].Formatted != "found" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+}
+
+func TestChainFallsThroughOnError(t *testing.T) {
+	failing := &stubProvider{err: errors.New("boom")}
+	good := &stubProvider{result: found("found")}
+
+	c := NewChain(failing, good)
+	result, err := c.Geocode("query", nil)
+	if err != nil {
+		t.Fatalf("Geocode returned error: %v", err)
+	}
+	if result.Results[0].Formatted != "found" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+}
+
+func TestChainReturnsErrNoResultsWhenAllEmpty(t *testing.T) {
+	c := NewChain(&stubProvider{result: &GeocodeResult{}}, &stubProvider{result: &GeocodeResult{}})
+	if _, err := c.Geocode("query", nil); err != ErrNoResults {
+		t.Fatalf("err = %v, want ErrNoResults", err)
+	}
+}
+
+func TestChainReturnsLastErrorWhenAllFail(t *testing.T) {
+	want := errors.New("boom")
+	c := NewChain(&stubProvider{err: errors.New("first")}, &stubProvider{err: want})
+	if _, err := c.Geocode("query", nil); err != want {
+		t.Fatalf("err = %v, want %v", err, want)
+	}
+}
+
+func TestRaceReturnsFirstSuccessfulResult(t *testing.T) {
+	failing := &stubProvider{err: errors.New("boom")}
+	good := &stubProvider{result: found("found")}
+
+	r := NewRace(failing, good)
+	result, err := r.Geocode("query", nil)
+	if err != nil {
+		t.Fatalf("Geocode returned error: %v", err)
+	}
+	if result.Results[0].Formatted != "found" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+}
+
+func TestRaceReturnsErrNoResultsWhenAllEmpty(t *testing.T) {
+	r := NewRace(&stubProvider{result: &GeocodeResult{}}, &stubProvider{result: &GeocodeResult{}})
+	if _, err := r.Geocode("query", nil); err != ErrNoResults {
+		t.Fatalf("err = %v, want ErrNoResults", err)
+	}
+}